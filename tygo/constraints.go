@@ -0,0 +1,139 @@
+package tygo
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// comparableConstraint is the TS alias substituted for Go's `comparable`
+// constraint: every type whose values can be compared with `===`.
+const comparableConstraint = "string | number | boolean | bigint | symbol | null | undefined"
+
+// ConstraintAlias is a generic type-parameter constraint that couldn't be
+// inlined into its `extends` clause, along with the TS it should resolve
+// to. Callers collect these across every generic type in a file and emit
+// each unique Name once, ahead of the types that reference it.
+type ConstraintAlias struct {
+	Name string
+	Body string
+}
+
+func (a ConstraintAlias) declName() string { return a.Name }
+
+// DeduplicateConstraintAliases keeps the first definition seen for each
+// alias name, preserving order, so the same `<Param>Constraint` used by
+// multiple generic types in a package is only emitted once.
+func DeduplicateConstraintAliases(aliases []ConstraintAlias) []ConstraintAlias {
+	return dedupeNamed(aliases)
+}
+
+// WriteConstraintAliases emits `type Name = Body;` for each alias, meant to
+// be called once per file ahead of the generic types that reference them.
+func (g *PackageGenerator) WriteConstraintAliases(s *strings.Builder, aliases []ConstraintAlias) {
+	writeNamedDecls(s, aliases, func(s *strings.Builder, a ConstraintAlias) {
+		s.WriteString("type ")
+		s.WriteString(a.Name)
+		s.WriteString(" = ")
+		s.WriteString(a.Body)
+		s.WriteString(";\n")
+	})
+}
+
+// writeConstraint writes a single type parameter's constraint and returns
+// any helper aliases it had to synthesize along the way.
+func (g *PackageGenerator) writeConstraint(s *strings.Builder, paramName string, t ast.Expr) []ConstraintAlias {
+	switch t := t.(type) {
+	case *ast.Ident:
+		switch t.String() {
+		case "comparable":
+			s.WriteString(comparableConstraint)
+			return nil
+		case "any":
+			s.WriteString(getIdent(g.conf.FallbackType))
+			return nil
+		}
+		g.writeType(s, t, 0, true)
+		return nil
+
+	case *ast.InterfaceType:
+		if len(t.Methods.List) == 1 {
+			g.writeConstraintField(s, t.Methods.List[0])
+			return nil
+		}
+		if len(t.Methods.List) > 1 {
+			// Two or more fields need an alias regardless of whether they're
+			// methods, type-set elements, or a mix: TS has no inline syntax
+			// for an intersection, so the pieces are hoisted out and joined
+			// with "&" in a standalone `type <Param>Constraint = ...`. Each
+			// piece is parenthesized, since a type-set union would otherwise
+			// bind looser than the "&".
+			alias := paramName + "Constraint"
+			var body strings.Builder
+			for i, f := range t.Methods.List {
+				if i > 0 {
+					body.WriteString(" & ")
+				}
+				body.WriteByte('(')
+				g.writeConstraintField(&body, f)
+				body.WriteByte(')')
+			}
+			s.WriteString(alias)
+			return []ConstraintAlias{{Name: alias, Body: body.String()}}
+		}
+		s.WriteString(getIdent(g.conf.FallbackType))
+		return nil
+
+	default:
+		// A type-set union (`~int | ~string | MyType`) is parsed as a bare
+		// BinaryExpr/UnaryExpr chain, without an enclosing interface{}.
+		g.writeConstraintExpr(s, t)
+		return nil
+	}
+}
+
+// writeConstraintField renders one member of a constraint's interface body:
+// a method becomes an object type `{ Name(args): Ret }` via writeFuncSignature
+// (the same path writeInterfaceMethodSet uses), anything else is a type-set
+// element handled by writeConstraintExpr. Methods must never be routed
+// through writeConstraintExpr: its *ast.FuncType fallback (writeFuncType)
+// renders an anonymous arrow type and silently drops the method's name.
+func (g *PackageGenerator) writeConstraintField(s *strings.Builder, f *ast.Field) {
+	if ft, isFunc := f.Type.(*ast.FuncType); isFunc && len(f.Names) != 0 {
+		s.WriteString("{ ")
+		s.WriteString(f.Names[0].Name)
+		g.writeFuncSignature(s, ft, 0)
+		s.WriteString(" }")
+		return
+	}
+	g.writeConstraintExpr(s, f.Type)
+}
+
+// writeConstraintExpr renders a type-set expression (`~int | ~string | T`)
+// as a TS union, stripping Go's tilde (TS has no "underlying type" notion)
+// and mapping primitives the same way writeType does.
+func (g *PackageGenerator) writeConstraintExpr(s *strings.Builder, t ast.Expr) {
+	switch t := t.(type) {
+	case *ast.BinaryExpr:
+		g.writeConstraintExpr(s, t.X)
+		s.WriteString(" | ")
+		g.writeConstraintExpr(s, t.Y)
+	case *ast.UnaryExpr:
+		if t.Op == token.TILDE {
+			g.writeConstraintExpr(s, t.X)
+			return
+		}
+		g.writeType(s, t, 0, false)
+	case *ast.Ident:
+		switch t.String() {
+		case "comparable":
+			s.WriteString(comparableConstraint)
+		case "any":
+			s.WriteString(getIdent(g.conf.FallbackType))
+		default:
+			g.writeType(s, t, 0, false)
+		}
+	default:
+		g.writeType(s, t, 0, false)
+	}
+}
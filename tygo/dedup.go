@@ -0,0 +1,34 @@
+package tygo
+
+import "strings"
+
+// namedDecl is anything identified by a unique declaration name, e.g. a
+// ConstraintAlias or GraphQLScalar collected while walking a file's types.
+type namedDecl interface {
+	declName() string
+}
+
+// dedupeNamed keeps the first declaration seen for each name, preserving
+// order, so the same helper declaration referenced by multiple generated
+// types in a package is only emitted once.
+func dedupeNamed[T namedDecl](items []T) []T {
+	seen := make(map[string]bool, len(items))
+	out := make([]T, 0, len(items))
+	for _, it := range items {
+		name := it.declName()
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		out = append(out, it)
+	}
+	return out
+}
+
+// writeNamedDecls renders each item with render, meant to be called once per
+// file ahead of the types that reference them.
+func writeNamedDecls[T namedDecl](s *strings.Builder, items []T, render func(*strings.Builder, T)) {
+	for _, it := range items {
+		render(s, it)
+	}
+}
@@ -0,0 +1,30 @@
+package tygo
+
+import "strings"
+
+// DiscriminatedMember is one concrete struct implementing a discriminated
+// interface, as discovered by walking a `tstype:"...,discriminator=value"`
+// tagged field via writeStructFields.
+type DiscriminatedMember struct {
+	TypeName           string
+	DiscriminatorValue string
+}
+
+// WriteDiscriminatedUnion renders `type Name = A | B | C;` for a Go
+// interface whose implementors were tagged with a common discriminator
+// field. Members are written in the order they're passed in, which should
+// match declaration order in the source package.
+func (g *PackageGenerator) WriteDiscriminatedUnion(s *strings.Builder, name string, members []DiscriminatedMember) {
+	s.WriteString("type ")
+	s.WriteString(name)
+	s.WriteString(" =\n")
+	for i, m := range members {
+		s.WriteString(g.conf.Indent)
+		s.WriteString(m.TypeName)
+		if i != len(members)-1 {
+			s.WriteString(" |\n")
+		} else {
+			s.WriteString(";\n")
+		}
+	}
+}
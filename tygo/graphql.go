@@ -0,0 +1,134 @@
+package tygo
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// GraphQLScalar is a generated placeholder scalar, e.g. for a Go map field
+// that has no GraphQL equivalent. Callers collect these across a file the
+// same way they collect ConstraintAlias values, and emit each unique Name
+// once via WriteGraphQLScalars.
+type GraphQLScalar struct {
+	Name string
+}
+
+func (sc GraphQLScalar) declName() string { return sc.Name }
+
+// DeduplicateGraphQLScalars keeps the first declaration seen for each
+// scalar name, preserving order.
+func DeduplicateGraphQLScalars(scalars []GraphQLScalar) []GraphQLScalar {
+	return dedupeNamed(scalars)
+}
+
+// WriteGraphQLScalars emits `scalar Name` for each scalar, meant to be
+// called once per schema ahead of the types that reference them.
+func (g *PackageGenerator) WriteGraphQLScalars(s *strings.Builder, scalars []GraphQLScalar) {
+	writeNamedDecls(s, scalars, func(s *strings.Builder, sc GraphQLScalar) {
+		s.WriteString("scalar ")
+		s.WriteString(sc.Name)
+		s.WriteByte('\n')
+	})
+}
+
+// WriteGraphQLStruct emits a GraphQL `type` for a Go struct, driven by the
+// same field walk (tag renaming, omitempty, tstype skips) collectGuardFields
+// already does for the typeguard/zod emitters. It's the "graphql" counterpart
+// to writeStructFields, selected via `conf.Emit: []string{"ts", "graphql"}`.
+func (g *PackageGenerator) WriteGraphQLStruct(s *strings.Builder, structName string, fields []*ast.Field) []GraphQLScalar {
+	gfs := g.collectGuardFields(fields)
+
+	var scalars []GraphQLScalar
+	s.WriteString(fmt.Sprintf("type %s {\n", structName))
+	for _, f := range gfs {
+		gqlType, scalar := g.graphqlTypeFor(structName, f.name, f.typ)
+		if scalar != nil {
+			scalars = append(scalars, *scalar)
+		}
+		if f.optional {
+			gqlType = strings.TrimSuffix(gqlType, "!")
+		}
+
+		s.WriteString(g.conf.Indent)
+		s.WriteString(f.name)
+		s.WriteString(": ")
+		s.WriteString(gqlType)
+		s.WriteByte('\n')
+	}
+	s.WriteString("}\n")
+	return scalars
+}
+
+// WriteGraphQLUnion emits `union Name = A | B | C` for a discriminated Go
+// interface, using the same member list writeStructFields/WriteDiscriminatedUnion
+// gather for the TS union.
+func (g *PackageGenerator) WriteGraphQLUnion(s *strings.Builder, name string, members []DiscriminatedMember) {
+	s.WriteString("union ")
+	s.WriteString(name)
+	s.WriteString(" =")
+	for i, m := range members {
+		if i > 0 {
+			s.WriteString(" |")
+		}
+		s.WriteByte(' ')
+		s.WriteString(m.TypeName)
+	}
+	s.WriteByte('\n')
+}
+
+// graphqlTypeFor maps a Go field type to a GraphQL SDL type, non-null by
+// default ("!"); the caller strips the trailing "!" for optional fields.
+// Returns a non-nil scalar when the type needed a generated placeholder
+// scalar (maps, and selector types with no mapping).
+func (g *PackageGenerator) graphqlTypeFor(structName, fieldName string, t ast.Expr) (string, *GraphQLScalar) {
+	switch t := t.(type) {
+	case *ast.StarExpr:
+		// A nested pointer (e.g. []*Item or map[string]*Thing) is nullable,
+		// unlike a top-level pointer field, which collectGuardFields already
+		// unwraps and whose optionality is applied by stripping the trailing
+		// "!" in WriteGraphQLStruct.
+		elem, scalar := g.graphqlTypeFor(structName, fieldName, t.X)
+		return strings.TrimSuffix(elem, "!"), scalar
+	case *ast.ArrayType:
+		if v, ok := t.Elt.(*ast.Ident); ok && v.String() == "byte" {
+			return "String!", nil
+		}
+		// elem is already correctly non-null ("Foo!") or nullable ("Foo"),
+		// per the StarExpr case above, so it's used as-is as the list's
+		// item type rather than unconditionally forcing it non-null.
+		elem, scalar := g.graphqlTypeFor(structName, fieldName, t.Elt)
+		return "[" + elem + "]!", scalar
+	case *ast.Ident:
+		switch t.String() {
+		case "string":
+			return "String!", nil
+		case "bool":
+			return "Boolean!", nil
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64":
+			return "Int!", nil
+		case "float32", "float64":
+			return "Float!", nil
+		default:
+			// Reference to another generated GraphQL type.
+			return t.String() + "!", nil
+		}
+	case *ast.MapType:
+		scalarName := structName + strings.ToUpper(fieldName[:1]) + fieldName[1:]
+		return scalarName + "!", &GraphQLScalar{Name: scalarName}
+	case *ast.SelectorExpr:
+		longType := fmt.Sprintf("%s.%s", t.X, t.Sel)
+		if mapped, ok := g.conf.GraphQLTypeMappings[longType]; ok {
+			return mapped + "!", nil
+		}
+		if longType == "time.Time" {
+			return "String!", nil
+		}
+		scalarName := structName + strings.ToUpper(fieldName[:1]) + fieldName[1:]
+		return scalarName + "!", &GraphQLScalar{Name: scalarName}
+	default:
+		scalarName := structName + strings.ToUpper(fieldName[:1]) + fieldName[1:]
+		return scalarName + "!", &GraphQLScalar{Name: scalarName}
+	}
+}
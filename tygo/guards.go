@@ -0,0 +1,362 @@
+package tygo
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+
+	"github.com/fatih/structtag"
+)
+
+// guardField is the subset of field metadata the guard/schema writers need.
+// It mirrors the tag resolution writeStructFields already does, so that
+// isFoo()/FooSchema stay in lock-step with the emitted TS interface.
+type guardField struct {
+	name     string
+	optional bool
+	typ      ast.Expr
+	// tstype is the raw `tstype:"..."` override, if any. When set, the
+	// field's TS type came from this string rather than from walking typ,
+	// so the guard/schema must check against it instead of the Go type.
+	tstype string
+}
+
+// collectGuardFields walks struct fields the same way writeStructFields does
+// (respecting `json`/`yaml` renames, `-` skips and `omitempty`) but without
+// writing any TS, so it can be shared between the typeguard, zod and io-ts
+// emitters. The tstype `discriminator=` option is not applied here: such a
+// field is checked against its Go type (or its tstype name, when one is
+// given), not against the string literal the TS interface gives it.
+func (g *PackageGenerator) collectGuardFields(fields []*ast.Field) []guardField {
+	var out []guardField
+fields:
+	for _, f := range fields {
+		var fieldName string
+		if len(f.Names) == 0 {
+			if name, valid := getAnonymousFieldName(f.Type); valid {
+				fieldName = name
+			}
+		}
+		if len(f.Names) != 0 && f.Names[0] != nil && len(f.Names[0].Name) != 0 {
+			fieldName = f.Names[0].Name
+		}
+		if len(fieldName) == 0 || 'A' > fieldName[0] || fieldName[0] > 'Z' {
+			continue
+		}
+
+		var name string
+		var tstype string
+		optional := false
+		required := false
+		typ := f.Type
+
+		if f.Tag != nil {
+			tags, err := structtag.Parse(f.Tag.Value[1 : len(f.Tag.Value)-1])
+			if err != nil {
+				panic(err)
+			}
+
+			for _, tagKey := range g.tagPriority() {
+				resolver, ok := tagResolvers[tagKey]
+				if !ok {
+					continue
+				}
+
+				resolvedName, present, skip := resolver.Name(tags)
+				if skip {
+					continue fields
+				}
+				if !present {
+					continue
+				}
+
+				name = resolvedName
+				optional = resolver.Optional(tags)
+				break
+			}
+
+			if tstypeTag, err := tags.Get("tstype"); err == nil {
+				if tstypeTag.Name == "-" || tstypeTag.HasOption("extends") {
+					continue
+				}
+				tstype = tstypeTag.Name
+				required = tstypeTag.HasOption("required")
+			}
+		}
+
+		if len(name) == 0 {
+			if g.conf.Flavor == "yaml" {
+				name = strings.ToLower(fieldName)
+			} else {
+				name = fieldName
+			}
+		}
+
+		if star, ok := typ.(*ast.StarExpr); ok {
+			optional = !required
+			typ = star.X
+		}
+
+		out = append(out, guardField{name: name, optional: optional, typ: typ, tstype: tstype})
+	}
+	return out
+}
+
+// WriteGuard emits a companion runtime validator for a generated interface,
+// according to g.conf.EmitGuards ("typeguard", "zod" or "iots"). It is a
+// no-op when EmitGuards is unset, so callers can invoke it unconditionally
+// right after writing the TS interface for a struct.
+func (g *PackageGenerator) WriteGuard(s *strings.Builder, name string, fields []*ast.Field) {
+	gfs := g.collectGuardFields(fields)
+	switch g.conf.EmitGuards {
+	case "typeguard":
+		g.writeTypeGuardFunc(s, name, gfs)
+	case "zod":
+		g.writeZodSchema(s, name, gfs)
+	case "iots":
+		g.writeIoTsType(s, name, gfs)
+	}
+}
+
+func guardAccessor(fieldName string) string {
+	if validJSName(fieldName) {
+		return "o." + fieldName
+	}
+	return "o[" + fmt.Sprintf("%q", fieldName) + "]"
+}
+
+// typeGuardExpr returns a boolean expression that checks accessor against t,
+// assuming accessor is not undefined (optionality is handled by the caller).
+func (g *PackageGenerator) typeGuardExpr(t ast.Expr, accessor string) string {
+	switch t := t.(type) {
+	case *ast.StarExpr:
+		return accessor + " === undefined || (" + g.typeGuardExpr(t.X, accessor) + ")"
+	case *ast.ArrayType:
+		if v, ok := t.Elt.(*ast.Ident); ok && v.String() == "byte" {
+			return "typeof " + accessor + " === 'string'"
+		}
+		return "Array.isArray(" + accessor + ") && " + accessor + ".every((v) => " + g.typeGuardExpr(t.Elt, "v") + ")"
+	case *ast.Ident:
+		switch t.String() {
+		case "string":
+			return "typeof " + accessor + " === 'string'"
+		case "bool":
+			return "typeof " + accessor + " === 'boolean'"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64":
+			return "typeof " + accessor + " === 'number'"
+		default:
+			// Assume a sibling interface guard (e.g. isFoo) was generated.
+			return "is" + t.String() + "(" + accessor + ")"
+		}
+	case *ast.MapType:
+		return "typeof " + accessor + " === 'object' && " + accessor + " !== null"
+	case *ast.SelectorExpr:
+		longType := fmt.Sprintf("%s.%s", t.X, t.Sel)
+		if longType == "time.Time" {
+			return "typeof " + accessor + " === 'string'"
+		}
+		return "true /* " + longType + " */"
+	default:
+		return "true"
+	}
+}
+
+// typeGuardExprForOverride mirrors typeGuardExpr for a `tstype:"..."`
+// override string instead of a Go ast.Expr. Only the primitive TS types are
+// checked structurally; anything else is assumed to name another generated
+// type and gets its sibling guard called, same as the default *ast.Ident case.
+func typeGuardExprForOverride(override, accessor string) string {
+	switch override {
+	case "string":
+		return "typeof " + accessor + " === 'string'"
+	case "number":
+		return "typeof " + accessor + " === 'number'"
+	case "boolean":
+		return "typeof " + accessor + " === 'boolean'"
+	default:
+		return "is" + override + "(" + accessor + ")"
+	}
+}
+
+func (g *PackageGenerator) writeTypeGuardFunc(s *strings.Builder, name string, gfs []guardField) {
+	s.WriteString(fmt.Sprintf("export function is%s(x: unknown): x is %s {\n", name, name))
+	s.WriteString(g.conf.Indent + "if (typeof x !== 'object' || x === null) return false;\n")
+	s.WriteString(g.conf.Indent + "const o = x as Record<string, unknown>;\n")
+
+	if len(gfs) == 0 {
+		s.WriteString(g.conf.Indent + "return true;\n}\n")
+		return
+	}
+
+	s.WriteString(g.conf.Indent + "return (\n")
+	for i, f := range gfs {
+		accessor := guardAccessor(f.name)
+		var expr string
+		if f.tstype != "" {
+			expr = typeGuardExprForOverride(f.tstype, accessor)
+		} else {
+			expr = g.typeGuardExpr(f.typ, accessor)
+		}
+		if f.optional {
+			expr = "(" + accessor + " === undefined || (" + expr + "))"
+		}
+		s.WriteString(g.conf.Indent + g.conf.Indent + expr)
+		if i != len(gfs)-1 {
+			s.WriteString(" &&\n")
+		} else {
+			s.WriteByte('\n')
+		}
+	}
+	s.WriteString(g.conf.Indent + ");\n}\n")
+}
+
+// zodTypeFor maps a Go field type to a zod schema expression. Unknown types
+// fall back to z.unknown() so a Zod schema always compiles, even if it
+// under-validates that particular field.
+func (g *PackageGenerator) zodTypeFor(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.StarExpr:
+		return g.zodTypeFor(t.X) + ".optional()"
+	case *ast.ArrayType:
+		if v, ok := t.Elt.(*ast.Ident); ok && v.String() == "byte" {
+			return "z.string()"
+		}
+		return "z.array(" + g.zodTypeFor(t.Elt) + ")"
+	case *ast.Ident:
+		switch t.String() {
+		case "string":
+			return "z.string()"
+		case "bool":
+			return "z.boolean()"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64":
+			return "z.number()"
+		default:
+			return t.String() + "Schema"
+		}
+	case *ast.MapType:
+		return "z.record(" + g.zodTypeFor(t.Value) + ")"
+	case *ast.SelectorExpr:
+		longType := fmt.Sprintf("%s.%s", t.X, t.Sel)
+		if mapped, ok := g.conf.TypeMappings[longType]; ok {
+			return mapped
+		}
+		if longType == "time.Time" {
+			return "z.string().datetime()"
+		}
+		return "z.unknown() /* " + longType + " */"
+	default:
+		return "z.unknown()"
+	}
+}
+
+// zodTypeForOverride mirrors zodTypeFor for a `tstype:"..."` override string.
+func zodTypeForOverride(override string) string {
+	switch override {
+	case "string":
+		return "z.string()"
+	case "number":
+		return "z.number()"
+	case "boolean":
+		return "z.boolean()"
+	default:
+		return override + "Schema"
+	}
+}
+
+func (g *PackageGenerator) writeZodSchema(s *strings.Builder, name string, gfs []guardField) {
+	s.WriteString(fmt.Sprintf("export const %sSchema = z.object({\n", name))
+	for _, f := range gfs {
+		var zodExpr string
+		if f.tstype != "" {
+			zodExpr = zodTypeForOverride(f.tstype)
+		} else {
+			zodExpr = g.zodTypeFor(f.typ)
+		}
+		if f.optional && !strings.HasSuffix(zodExpr, ".optional()") {
+			zodExpr += ".optional()"
+		}
+		s.WriteString(g.conf.Indent)
+		s.WriteString(f.name)
+		s.WriteString(": ")
+		s.WriteString(zodExpr)
+		s.WriteString(",\n")
+	}
+	s.WriteString("});\n")
+	s.WriteString(fmt.Sprintf("export type %sFromSchema = z.infer<typeof %sSchema>;\n", name, name))
+}
+
+// ioTsTypeFor mirrors zodTypeFor but targets the io-ts `t.xxx` codec API.
+func (g *PackageGenerator) ioTsTypeFor(t ast.Expr) string {
+	switch t := t.(type) {
+	case *ast.StarExpr:
+		return "t.union([" + g.ioTsTypeFor(t.X) + ", t.undefined])"
+	case *ast.ArrayType:
+		if v, ok := t.Elt.(*ast.Ident); ok && v.String() == "byte" {
+			return "t.string"
+		}
+		return "t.array(" + g.ioTsTypeFor(t.Elt) + ")"
+	case *ast.Ident:
+		switch t.String() {
+		case "string":
+			return "t.string"
+		case "bool":
+			return "t.boolean"
+		case "int", "int8", "int16", "int32", "int64",
+			"uint", "uint8", "uint16", "uint32", "uint64",
+			"float32", "float64":
+			return "t.number"
+		default:
+			return t.String() + "Type"
+		}
+	case *ast.MapType:
+		return "t.record(t.string, " + g.ioTsTypeFor(t.Value) + ")"
+	case *ast.SelectorExpr:
+		longType := fmt.Sprintf("%s.%s", t.X, t.Sel)
+		if longType == "time.Time" {
+			return "t.string"
+		}
+		return "t.unknown /* " + longType + " */"
+	default:
+		return "t.unknown"
+	}
+}
+
+// ioTsTypeForOverride mirrors ioTsTypeFor for a `tstype:"..."` override string.
+func ioTsTypeForOverride(override string) string {
+	switch override {
+	case "string":
+		return "t.string"
+	case "number":
+		return "t.number"
+	case "boolean":
+		return "t.boolean"
+	default:
+		return override + "Type"
+	}
+}
+
+func (g *PackageGenerator) writeIoTsType(s *strings.Builder, name string, gfs []guardField) {
+	s.WriteString(fmt.Sprintf("export const %sType = t.type({\n", name))
+	for _, f := range gfs {
+		var ioExpr string
+		if f.tstype != "" {
+			ioExpr = ioTsTypeForOverride(f.tstype)
+		} else {
+			ioExpr = g.ioTsTypeFor(f.typ)
+		}
+		s.WriteString(g.conf.Indent)
+		s.WriteString(f.name)
+		s.WriteString(": ")
+		if f.optional {
+			s.WriteString("t.union([" + ioExpr + ", t.undefined])")
+		} else {
+			s.WriteString(ioExpr)
+		}
+		s.WriteString(",\n")
+	}
+	s.WriteString("});\n")
+}
@@ -0,0 +1,122 @@
+package tygo
+
+import (
+	"strings"
+
+	"github.com/fatih/structtag"
+)
+
+// TagResolver maps one struct-tag flavor (json, yaml, protobuf, ...) to the
+// name/optionality/readonly-ness it implies for a field. writeStructFields
+// walks g.tagPriority() and uses the first resolver whose tag is present on
+// the field, so a package can say e.g. TagPriority: []string{"protobuf",
+// "json"} to have protobuf names win over json ones instead of whichever
+// tag happened to be parsed last.
+type TagResolver interface {
+	// Name returns the field name the tag implies. present is false when the
+	// tag isn't on the field at all (try the next resolver in priority
+	// order); skip is true when the tag explicitly excludes the field (e.g.
+	// `json:"-"`), which should stop the walk and drop the field entirely.
+	Name(tags *structtag.Tags) (name string, present bool, skip bool)
+	Optional(tags *structtag.Tags) bool
+	Readonly(tags *structtag.Tags) bool
+}
+
+// tagResolvers is the built-in registry; TagPriority picks among these by
+// name.
+var tagResolvers = map[string]TagResolver{
+	"json":         simpleTagResolver{key: "json"},
+	"yaml":         simpleTagResolver{key: "yaml"},
+	"toml":         simpleTagResolver{key: "toml"},
+	"mapstructure": simpleTagResolver{key: "mapstructure"},
+	"protobuf":     protobufTagResolver{},
+}
+
+// tagPriority returns the package's configured tag priority. Without an
+// explicit TagPriority, this defaults to ["yaml", "json"] regardless of
+// Flavor, matching the old unconditional behavior (yaml was parsed after
+// json and always overwrote its name/optional), so existing dual-tagged
+// structs keep generating the same field names they always did. The one
+// difference: a field tagged both `json:"-"` and `yaml:"name"` used to be
+// dropped, and is now emitted under its yaml name, since the walk stops at
+// the present yaml tag and never reaches json. Set
+// TagPriority explicitly (e.g. []string{"json", "yaml"} or a protobuf-first
+// order) to opt into a different resolution order.
+func (g *PackageGenerator) tagPriority() []string {
+	if len(g.conf.TagPriority) != 0 {
+		return g.conf.TagPriority
+	}
+	return []string{"yaml", "json"}
+}
+
+// simpleTagResolver handles the common case of a tag whose value is the
+// field name, with an `omitempty` option marking it optional: json, yaml,
+// toml and mapstructure all follow this shape.
+type simpleTagResolver struct {
+	key string
+}
+
+func (r simpleTagResolver) Name(tags *structtag.Tags) (string, bool, bool) {
+	t, err := tags.Get(r.key)
+	if err != nil {
+		return "", false, false
+	}
+	if t.Name == "-" {
+		return "", false, true
+	}
+	return t.Name, true, false
+}
+
+func (r simpleTagResolver) Optional(tags *structtag.Tags) bool {
+	t, err := tags.Get(r.key)
+	if err != nil {
+		return false
+	}
+	return t.HasOption("omitempty")
+}
+
+func (r simpleTagResolver) Readonly(*structtag.Tags) bool {
+	return false
+}
+
+// protobufTagResolver parses tags like `protobuf:"bytes,1,opt,name=foo,proto3"`,
+// where the name lives in a `name=` option rather than the tag's leading
+// value, and optionality is the bare `opt` token.
+type protobufTagResolver struct{}
+
+func protobufParts(tags *structtag.Tags) []string {
+	t, err := tags.Get("protobuf")
+	if err != nil {
+		return nil
+	}
+	return append([]string{t.Name}, t.Options...)
+}
+
+func (protobufTagResolver) Name(tags *structtag.Tags) (string, bool, bool) {
+	parts := protobufParts(tags)
+	if parts == nil {
+		return "", false, false
+	}
+	for _, part := range parts {
+		if value, ok := strings.CutPrefix(part, "name="); ok {
+			if value == "-" {
+				return "", false, true
+			}
+			return value, true, false
+		}
+	}
+	return "", false, false
+}
+
+func (protobufTagResolver) Optional(tags *structtag.Tags) bool {
+	for _, part := range protobufParts(tags) {
+		if part == "opt" {
+			return true
+		}
+	}
+	return false
+}
+
+func (protobufTagResolver) Readonly(*structtag.Tags) bool {
+	return false
+}
@@ -61,6 +61,8 @@ func (g *PackageGenerator) writeType(
 		s.WriteString("[]")
 	case *ast.StructType:
 		s.WriteString("{\n")
+		// Discriminator info is only meaningful for named top-level structs,
+		// not inline struct literals, so it's discarded here.
 		g.writeStructFields(s, t.Fields.List, depth+1)
 		g.writeIndent(s, depth+1)
 		s.WriteByte('}')
@@ -105,13 +107,17 @@ func (g *PackageGenerator) writeType(
 		g.writeType(s, t.Y, depth, false)
 	case *ast.InterfaceType:
 		g.writeInterfaceFields(s, t.Methods.List, depth+1)
-	case *ast.CallExpr, *ast.FuncType, *ast.ChanType:
+	case *ast.FuncType:
+		g.writeFuncType(s, t, depth)
+	case *ast.CallExpr, *ast.ChanType:
 		s.WriteString(g.conf.FallbackType)
 	case *ast.UnaryExpr:
 		switch t.Op {
 		case token.TILDE:
-			// We just ignore the tilde token, in Typescript extended types are
-			// put into the generic typing itself, which we can't support yet.
+			// Outside of a type parameter's constraint (handled by
+			// writeConstraint/writeConstraintExpr) there's nowhere in TS to
+			// put the "underlying type" restriction a tilde expresses, so we
+			// still just write the approximate/underlying type here.
 			g.writeType(s, t.X, depth, false)
 		case token.XOR:
 			s.WriteString("~")
@@ -146,13 +152,89 @@ func (g *PackageGenerator) writeType(
 	}
 }
 
-func (g *PackageGenerator) writeTypeParamsFields(s *strings.Builder, fields []*ast.Field) {
+// writeFuncParams writes `(arg0: T, ...)` for a func type, falling back to
+// argN for unnamed parameters. Shared by writeFuncType's arrow-function form
+// and writeFuncSignature's method form.
+func (g *PackageGenerator) writeFuncParams(s *strings.Builder, t *ast.FuncType, depth int) {
+	s.WriteByte('(')
+	if t.Params != nil {
+		i := 0
+		for _, p := range t.Params.List {
+			names := p.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{nil}
+			}
+			for _, n := range names {
+				if i > 0 {
+					s.WriteString(", ")
+				}
+				if n != nil && len(n.Name) != 0 {
+					s.WriteString(n.Name)
+				} else {
+					s.WriteString(fmt.Sprintf("arg%d", i))
+				}
+				s.WriteString(": ")
+				g.writeType(s, p.Type, depth, false)
+				i++
+			}
+		}
+	}
+	s.WriteByte(')')
+}
+
+// writeFuncResults writes a func type's result type: `void` for none, the
+// bare type for one, or a tuple `[A, B]` for multiple.
+func (g *PackageGenerator) writeFuncResults(s *strings.Builder, t *ast.FuncType, depth int) {
+	switch {
+	case t.Results == nil || len(t.Results.List) == 0:
+		s.WriteString("void")
+	case len(t.Results.List) == 1 && len(t.Results.List[0].Names) <= 1:
+		g.writeType(s, t.Results.List[0].Type, depth, false)
+	default:
+		s.WriteByte('[')
+		i := 0
+		for _, r := range t.Results.List {
+			names := r.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{nil}
+			}
+			for range names {
+				if i > 0 {
+					s.WriteString(", ")
+				}
+				g.writeType(s, r.Type, depth, false)
+				i++
+			}
+		}
+		s.WriteByte(']')
+	}
+}
+
+// writeFuncType renders a Go func type (e.g. a struct field typed `func(int) string`)
+// as a TypeScript arrow function type.
+func (g *PackageGenerator) writeFuncType(s *strings.Builder, t *ast.FuncType, depth int) {
+	g.writeFuncParams(s, t, depth)
+	s.WriteString(" => ")
+	g.writeFuncResults(s, t, depth)
+}
+
+// writeTypeParamsFields writes a generic type's `<T extends ...>` clause.
+// Type-set constraints (`~int | ~string | MyType`) are expanded into a TS
+// union instead of just dropping the tilde, and structural constraints
+// (a type set combined with a method set) are hoisted into a named
+// `<Param>Constraint` alias, since TS has no inline syntax for that mix. The
+// caller is responsible for deduplicating and emitting the returned aliases
+// once at the top of the file, e.g. via DeduplicateConstraintAliases and
+// WriteConstraintAliases.
+func (g *PackageGenerator) writeTypeParamsFields(s *strings.Builder, fields []*ast.Field) []ConstraintAlias {
+	var aliases []ConstraintAlias
+
 	s.WriteByte('<')
 	for i, f := range fields {
 		for j, ident := range f.Names {
 			s.WriteString(ident.Name)
 			s.WriteString(" extends ")
-			g.writeType(s, f.Type, 0, true)
+			aliases = append(aliases, g.writeConstraint(s, ident.Name, f.Type)...)
 
 			if i != len(fields)-1 || j != len(f.Names)-1 {
 				s.WriteString(", ")
@@ -160,6 +242,7 @@ func (g *PackageGenerator) writeTypeParamsFields(s *strings.Builder, fields []*a
 		}
 	}
 	s.WriteByte('>')
+	return aliases
 }
 
 func (g *PackageGenerator) writeInterfaceFields(
@@ -201,11 +284,59 @@ func (g *PackageGenerator) writeInterfaceFields(
 	}
 
 	if !didContainNonFuncFields {
+		if g.conf.EmitInterfaceMethods && len(fields) != 0 {
+			g.writeInterfaceMethodSet(s, fields, depth)
+			return
+		}
 		s.WriteString(g.conf.FallbackType)
 	}
 }
 
-func (g *PackageGenerator) writeStructFields(s *strings.Builder, fields []*ast.Field, depth int) {
+// writeInterfaceMethodSet renders an interface's method set as a TS object
+// type, e.g. `{ foo(arg0: number): string; }`. Only invoked for interfaces
+// that contain nothing but methods (conf.EmitInterfaceMethods opts in).
+func (g *PackageGenerator) writeInterfaceMethodSet(
+	s *strings.Builder,
+	methods []*ast.Field,
+	depth int,
+) {
+	s.WriteString("{\n")
+	for _, m := range methods {
+		ft, isFunc := m.Type.(*ast.FuncType)
+		if !isFunc || len(m.Names) == 0 {
+			continue
+		}
+
+		if g.PreserveTypeComments() {
+			g.writeCommentGroupIfNotNil(s, m.Doc, depth+1)
+		}
+		g.writeIndent(s, depth+1)
+		s.WriteString(m.Names[0].Name)
+		g.writeFuncSignature(s, ft, depth)
+		s.WriteString(";\n")
+	}
+	g.writeIndent(s, depth)
+	s.WriteByte('}')
+}
+
+// writeFuncSignature writes `(arg0: T, ...): R` for a method, sharing the
+// parameter/result translation with writeFuncType's arrow-function form.
+// It writes its own "): " separator rather than rendering writeFuncType's
+// "(args) => Result" and string-replacing it, since a nested func-typed
+// parameter or result would also contain "=> " and get corrupted.
+func (g *PackageGenerator) writeFuncSignature(s *strings.Builder, ft *ast.FuncType, depth int) {
+	g.writeFuncParams(s, ft, depth)
+	s.WriteString(": ")
+	g.writeFuncResults(s, ft, depth)
+}
+
+// writeStructFields writes the TS fields for a struct body. The returned
+// discriminator name/value are non-empty when one field carried a
+// `tstype:"...,discriminator=value"` tag; the package-level interface ->
+// union resolution uses them to build a discriminated union once every
+// implementing struct has been walked.
+func (g *PackageGenerator) writeStructFields(s *strings.Builder, fields []*ast.Field, depth int) (discriminatorField, discriminatorValue string) {
+fields:
 	for _, f := range fields {
 		// fmt.Println(f.Type)
 		optional := false
@@ -227,29 +358,31 @@ func (g *PackageGenerator) writeStructFields(s *strings.Builder, fields []*ast.F
 
 		var name string
 		var tstype string
+		isDiscriminator := false
 		if f.Tag != nil {
 			tags, err := structtag.Parse(f.Tag.Value[1 : len(f.Tag.Value)-1])
 			if err != nil {
 				panic(err)
 			}
 
-			jsonTag, err := tags.Get("json")
-			if err == nil {
-				name = jsonTag.Name
-				if name == "-" {
+			for _, tagKey := range g.tagPriority() {
+				resolver, ok := tagResolvers[tagKey]
+				if !ok {
 					continue
 				}
 
-				optional = jsonTag.HasOption("omitempty")
-			}
-			yamlTag, err := tags.Get("yaml")
-			if err == nil {
-				name = yamlTag.Name
-				if name == "-" {
+				resolvedName, present, skip := resolver.Name(tags)
+				if skip {
+					continue fields
+				}
+				if !present {
 					continue
 				}
 
-				optional = yamlTag.HasOption("omitempty")
+				name = resolvedName
+				optional = resolver.Optional(tags)
+				readonly = resolver.Readonly(tags)
+				break
 			}
 
 			tstypeTag, err := tags.Get("tstype")
@@ -259,7 +392,21 @@ func (g *PackageGenerator) writeStructFields(s *strings.Builder, fields []*ast.F
 					continue
 				}
 				required = tstypeTag.HasOption("required")
-				readonly = tstypeTag.HasOption("readonly")
+				readonly = readonly || tstypeTag.HasOption("readonly")
+
+				for _, opt := range tstypeTag.Options {
+					if value, ok := strings.CutPrefix(opt, "discriminator="); ok {
+						// The field becomes a literal ("circle") rather than
+						// its Go type, so `type Foo = Circle | Square` can
+						// narrow on it. The caller driving the interface ->
+						// union resolution records name/value (the resolved
+						// output field name, not the raw Go identifier) to
+						// build that union once every member struct is known.
+						tstype = fmt.Sprintf("%q", value)
+						isDiscriminator = true
+						discriminatorValue = value
+					}
+				}
 			}
 		}
 
@@ -271,6 +418,10 @@ func (g *PackageGenerator) writeStructFields(s *strings.Builder, fields []*ast.F
 			}
 		}
 
+		if isDiscriminator {
+			discriminatorField = name
+		}
+
 		if g.PreserveTypeComments() {
 			g.writeCommentGroupIfNotNil(s, f.Doc, depth+1)
 		}
@@ -316,4 +467,5 @@ func (g *PackageGenerator) writeStructFields(s *strings.Builder, fields []*ast.F
 		}
 
 	}
+	return discriminatorField, discriminatorValue
 }